@@ -0,0 +1,177 @@
+package ise
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheTTL is the time-to-live applied to cached entries when Client.CacheTTL is unset.
+const DefaultCacheTTL = 30 * time.Second
+
+// DefaultCacheSize is the maximum number of entries NewLRUCache holds before evicting.
+const DefaultCacheSize = 256
+
+// Cache is implemented by types that can serve as the Client's GET response cache.
+type Cache interface {
+	// Get returns the cached result for key, if present and not expired.
+	Get(key string) (Res, bool)
+	// Set stores res under key for the given ttl.
+	Set(key string, res Res, ttl time.Duration)
+	// Invalidate removes the cached entry for prefix itself, plus every cached
+	// entry nested under it (i.e. whose key starts with prefix+"/"). A key that
+	// merely extends prefix as a string, without the "/" delimiter, is left alone.
+	Invalidate(prefix string)
+}
+
+// WithCache enables GET response caching using cache. Caching is disabled by default.
+func WithCache(cache Cache) func(*Client) {
+	return func(client *Client) {
+		client.Cache = cache
+	}
+}
+
+// CacheTTL modifies how long cached GET responses remain valid, from the default of DefaultCacheTTL.
+func CacheTTL(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		client.CacheTTL = x
+	}
+}
+
+// CacheHits returns the number of GET requests served from the cache.
+func (client *Client) CacheHits() int64 {
+	return atomic.LoadInt64(&client.cacheHits)
+}
+
+// CacheMisses returns the number of GET requests that looked up the cache and
+// found the entry absent or expired. GET requests made while caching is disabled,
+// or carrying CacheBypass or NoCache, are not counted.
+func (client *Client) CacheMisses() int64 {
+	return atomic.LoadInt64(&client.cacheMisses)
+}
+
+type cacheModKey struct{}
+
+type cacheMod struct {
+	noCache     bool
+	cacheBypass bool
+}
+
+// NoCache makes this request skip the cache entirely: its result is neither
+// served from nor written to the cache.
+func NoCache() func(*Req) {
+	return func(req *Req) {
+		withCacheMod(req, func(m *cacheMod) { m.noCache = true })
+	}
+}
+
+// CacheBypass skips reading this request's result from the cache, forcing a fresh GET.
+// The fresh result still populates the cache unless combined with NoCache.
+func CacheBypass() func(*Req) {
+	return func(req *Req) {
+		withCacheMod(req, func(m *cacheMod) { m.cacheBypass = true })
+	}
+}
+
+func withCacheMod(req *Req, fn func(*cacheMod)) {
+	m, _ := req.HttpReq.Context().Value(cacheModKey{}).(*cacheMod)
+	if m == nil {
+		m = &cacheMod{}
+		req.HttpReq = req.HttpReq.WithContext(context.WithValue(req.HttpReq.Context(), cacheModKey{}, m))
+	}
+	fn(m)
+}
+
+func cacheModOf(ctx context.Context) cacheMod {
+	if m, ok := ctx.Value(cacheModKey{}).(*cacheMod); ok {
+		return *m
+	}
+	return cacheMod{}
+}
+
+// collectionPrefix returns path with its trailing /{id} segment removed, e.g.
+// "/ers/config/internaluser/abc" becomes "/ers/config/internaluser".
+func collectionPrefix(path string) string {
+	idx := strings.LastIndex(path, "/")
+	if idx <= 0 {
+		return path
+	}
+	return path[:idx]
+}
+
+// lruCache is the default TTL-aware, size-bounded Cache implementation returned by NewLRUCache.
+type lruCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	res     Res
+	expires time.Time
+}
+
+// NewLRUCache creates an in-memory Cache that evicts its least recently used
+// entry once it holds more than size entries.
+func NewLRUCache(size int) Cache {
+	return &lruCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache) Get(key string) (Res, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return Res{}, false
+	}
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+		return Res{}, false
+	}
+	c.ll.MoveToFront(el)
+	return entry.res, true
+}
+
+func (c *lruCache) Set(key string, res Res, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.ll.MoveToFront(el)
+		entry := el.Value.(*lruEntry)
+		entry.res = res
+		entry.expires = time.Now().Add(ttl)
+		return
+	}
+	el := c.ll.PushFront(&lruEntry{key: key, res: res, expires: time.Now().Add(ttl)})
+	c.items[key] = el
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		if oldest == nil {
+			break
+		}
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+func (c *lruCache) Invalidate(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, el := range c.items {
+		if key == prefix || strings.HasPrefix(key, prefix+"/") {
+			c.ll.Remove(el)
+			delete(c.items, key)
+		}
+	}
+}
@@ -3,14 +3,15 @@ package ise
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io"
-	"log"
 	"math"
 	"math/rand"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/tidwall/gjson"
@@ -43,6 +44,31 @@ type Client struct {
 	BackoffMaxDelay int
 	// Backoff delay factor
 	BackoffDelayFactor float64
+	// OnRequest is invoked before each HTTP attempt. Defaults to a hook that
+	// preserves the library's historical log.Printf debug output.
+	OnRequest func(context.Context, *RequestLog)
+	// OnResponse is invoked after each HTTP attempt, including failed ones.
+	// Defaults to a hook that preserves the library's historical log.Printf debug output.
+	OnResponse func(context.Context, *ResponseLog, error)
+	// OnRetry is invoked by Backoff/BackoffCtx with the outcome of each retry decision.
+	// Defaults to a hook that preserves the library's historical log.Printf debug/trace output.
+	OnRetry func(context.Context, *RetryLog)
+	// Cache, if set, enables GET response caching. Disabled by default.
+	Cache Cache
+	// CacheTTL is how long cached GET responses remain valid. Defaults to DefaultCacheTTL.
+	CacheTTL time.Duration
+	// cacheHits and cacheMisses back CacheHits and CacheMisses.
+	cacheHits, cacheMisses int64
+	// modErr holds the first error raised by a modifier, e.g. a TLS modifier
+	// given malformed input. Surfaced as NewClient's error return.
+	modErr error
+}
+
+// setModErr records err as NewClient's error return, keeping the first one raised.
+func (client *Client) setModErr(err error) {
+	if client.modErr == nil {
+		client.modErr = err
+	}
 }
 
 // NewClient creates a new ISE HTTP client.
@@ -68,12 +94,15 @@ func NewClient(url, usr, pwd string, mods ...func(*Client)) (Client, error) {
 		BackoffMinDelay:    DefaultBackoffMinDelay,
 		BackoffMaxDelay:    DefaultBackoffMaxDelay,
 		BackoffDelayFactor: DefaultBackoffDelayFactor,
+		OnRequest:          defaultRequestLogHook,
+		OnResponse:         defaultResponseLogHook,
+		OnRetry:            defaultRetryLogHook,
 	}
 
 	for _, mod := range mods {
 		mod(&client)
 	}
-	return client, nil
+	return client, client.modErr
 }
 
 // Insecure determines if insecure https connections are allowed. Default value is true.
@@ -118,9 +147,38 @@ func BackoffDelayFactor(x float64) func(*Client) {
 	}
 }
 
+// WithRequestHook replaces the default log.Printf-based request logging with fn,
+// allowing callers to route ISE request traffic into their own structured logger.
+func WithRequestHook(fn func(context.Context, *RequestLog)) func(*Client) {
+	return func(client *Client) {
+		client.OnRequest = fn
+	}
+}
+
+// WithResponseHook replaces the default log.Printf-based response logging with fn,
+// allowing callers to route ISE response traffic into their own structured logger.
+func WithResponseHook(fn func(context.Context, *ResponseLog, error)) func(*Client) {
+	return func(client *Client) {
+		client.OnResponse = fn
+	}
+}
+
+// WithRetryHook replaces the default log.Printf-based retry/backoff logging with fn,
+// allowing callers to route ISE retry bookkeeping into their own structured logger.
+func WithRetryHook(fn func(context.Context, *RetryLog)) func(*Client) {
+	return func(client *Client) {
+		client.OnRetry = fn
+	}
+}
+
 // NewReq creates a new Req request for this client.
 func (client Client) NewReq(method, uri string, body io.Reader, mods ...func(*Req)) Req {
-	httpReq, _ := http.NewRequest(method, client.Url+uri, body)
+	return client.NewReqCtx(context.Background(), method, uri, body, mods...)
+}
+
+// NewReqCtx creates a new Req request for this client bound to ctx.
+func (client Client) NewReqCtx(ctx context.Context, method, uri string, body io.Reader, mods ...func(*Req)) Req {
+	httpReq, _ := http.NewRequestWithContext(ctx, method, client.Url+uri, body)
 	httpReq.SetBasicAuth(client.Usr, client.Pwd)
 	httpReq.Header.Set("Accept", "application/json")
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -140,6 +198,19 @@ func (client Client) NewReq(method, uri string, body io.Reader, mods ...func(*Re
 //	req := client.NewReq("GET", "/ers/config/internaluser", nil)
 //	res, _ := client.Do(req)
 func (client *Client) Do(req Req) (Res, error) {
+	return client.DoCtx(context.Background(), req)
+}
+
+// DoCtx makes a request bound to ctx. It aborts immediately, without consuming
+// the remaining backoff schedule, as soon as ctx is cancelled or its deadline expires.
+func (client *Client) DoCtx(ctx context.Context, req Req) (Res, error) {
+	res, _, err := client.do(ctx, req)
+	return res, err
+}
+
+// do makes a request and additionally returns the response headers, so that
+// callers such as Post can pick values like Location out of them.
+func (client *Client) do(ctx context.Context, req Req) (Res, http.Header, error) {
 	// retain the request body across multiple attempts
 	var body []byte
 	if req.HttpReq.Body != nil {
@@ -147,99 +218,174 @@ func (client *Client) Do(req Req) (Res, error) {
 	}
 
 	var res Res
+	var header http.Header
 
 	for attempts := 0; ; attempts++ {
+		if ctx.Err() != nil {
+			client.OnResponse(ctx, &ResponseLog{Method: req.HttpReq.Method, URL: req.HttpReq.URL.String(), Attempt: attempts + 1}, ctx.Err())
+			return res, header, ctx.Err()
+		}
 		req.HttpReq.Body = io.NopCloser(bytes.NewBuffer(body))
+		reqLog := &RequestLog{
+			Method:  req.HttpReq.Method,
+			URL:     req.HttpReq.URL.String(),
+			Header:  req.HttpReq.Header,
+			Attempt: attempts + 1,
+		}
 		if req.LogPayload {
-			log.Printf("[DEBUG] HTTP Request: %s, %s, %s", req.HttpReq.Method, req.HttpReq.URL, req.HttpReq.Body)
-		} else {
-			log.Printf("[DEBUG] HTTP Request: %s, %s", req.HttpReq.Method, req.HttpReq.URL)
+			reqLog.Body = string(body)
 		}
+		client.OnRequest(ctx, reqLog)
+		start := time.Now()
 
 		httpRes, err := client.HttpClient.Do(req.HttpReq)
 		if err != nil {
-			if ok := client.Backoff(attempts); !ok {
-				log.Printf("[ERROR] HTTP Connection error occured: %+v", err)
-				log.Printf("[DEBUG] Exit from Do method")
-				return Res{}, err
-			} else {
-				log.Printf("[ERROR] HTTP Connection failed: %s, retries: %v", err, attempts)
-				continue
+			ok := client.BackoffCtx(ctx, attempts)
+			client.OnResponse(ctx, &ResponseLog{Method: reqLog.Method, URL: reqLog.URL, Duration: time.Since(start), Attempt: reqLog.Attempt, WillRetry: ok}, err)
+			if !ok {
+				return Res{}, nil, err
 			}
+			continue
 		}
 
 		defer httpRes.Body.Close()
 		bodyBytes, err := io.ReadAll(httpRes.Body)
 		if err != nil {
-			if ok := client.Backoff(attempts); !ok {
-				log.Printf("[ERROR] Cannot decode response body: %+v", err)
-				log.Printf("[DEBUG] Exit from Do method")
-				return Res{}, err
-			} else {
-				log.Printf("[ERROR] Cannot decode response body: %s, retries: %v", err, attempts)
-				continue
+			ok := client.BackoffCtx(ctx, attempts)
+			client.OnResponse(ctx, &ResponseLog{Method: reqLog.Method, URL: reqLog.URL, Header: httpRes.Header, Status: httpRes.StatusCode, Duration: time.Since(start), Attempt: reqLog.Attempt, WillRetry: ok}, err)
+			if !ok {
+				return Res{}, nil, err
 			}
+			continue
 		}
 		res = Res(gjson.ParseBytes(bodyBytes))
-		if req.LogPayload {
-			log.Printf("[DEBUG] HTTP Response: %s", res.Raw)
-		}
+		header = httpRes.Header
 
 		if httpRes.StatusCode >= 200 && httpRes.StatusCode <= 299 {
-			log.Printf("[DEBUG] Exit from Do method")
-			break
-		} else {
-			errMessage := res.Get("ERSResponse.messages.0.title").Str
-			if ok := client.Backoff(attempts); !ok {
-				log.Printf("[ERROR] HTTP Request failed: StatusCode %v, Message: %v", httpRes.StatusCode, errMessage)
-				log.Printf("[DEBUG] Exit from Do method")
-				return res, fmt.Errorf("HTTP Request failed: StatusCode %v, Message: %v", httpRes.StatusCode, errMessage)
-			} else if httpRes.StatusCode == 408 || (httpRes.StatusCode >= 502 && httpRes.StatusCode <= 504) {
-				log.Printf("[ERROR] HTTP Request failed: StatusCode %v, Message: %v, Retries: %v", httpRes.StatusCode, errMessage, attempts)
-				continue
-			} else {
-				log.Printf("[ERROR] HTTP Request failed: StatusCode %v, Message: %v", httpRes.StatusCode, errMessage)
-				log.Printf("[DEBUG] Exit from Do method")
-				return res, fmt.Errorf("HTTP Request failed: StatusCode %v, Message: %v", httpRes.StatusCode, errMessage)
+			resLog := &ResponseLog{Method: reqLog.Method, URL: reqLog.URL, Header: httpRes.Header, Status: httpRes.StatusCode, Duration: time.Since(start), Attempt: reqLog.Attempt}
+			if req.LogPayload {
+				resLog.Body = res.Raw
 			}
+			client.OnResponse(ctx, resLog, nil)
+			break
+		}
+
+		errMessage := res.Get("ERSResponse.messages.0.title").Str
+		ok := client.BackoffCtx(ctx, attempts)
+		retryable := httpRes.StatusCode == 408 || (httpRes.StatusCode >= 502 && httpRes.StatusCode <= 504)
+		willRetry := ok && retryable
+		client.OnResponse(ctx, &ResponseLog{Method: reqLog.Method, URL: reqLog.URL, Header: httpRes.Header, Status: httpRes.StatusCode, Duration: time.Since(start), Attempt: reqLog.Attempt, ErrorMessage: errMessage, WillRetry: willRetry}, nil)
+		if willRetry {
+			continue
 		}
+		return res, header, fmt.Errorf("HTTP Request failed: StatusCode %v, Message: %v", httpRes.StatusCode, errMessage)
 	}
 
-	return res, nil
+	return res, header, nil
 }
 
 // Get makes a GET request and returns a GJSON result.
 // Results will be the raw data structure as returned by vManage
 func (client *Client) Get(path string, mods ...func(*Req)) (Res, error) {
-	req := client.NewReq("GET", path, nil, mods...)
-	return client.Do(req)
+	return client.GetCtx(context.Background(), path, mods...)
+}
+
+// GetCtx makes a GET request bound to ctx and returns a GJSON result.
+// If a Cache is configured (see WithCache), the result is served from the
+// cache unless the request carries CacheBypass or NoCache, and saved to the
+// cache unless the request carries NoCache.
+func (client *Client) GetCtx(ctx context.Context, path string, mods ...func(*Req)) (Res, error) {
+	req := client.NewReqCtx(ctx, "GET", path, nil, mods...)
+	mod := cacheModOf(req.HttpReq.Context())
+	key := client.Url + path
+
+	if client.Cache != nil && !mod.cacheBypass && !mod.noCache {
+		if res, ok := client.Cache.Get(key); ok {
+			atomic.AddInt64(&client.cacheHits, 1)
+			return res, nil
+		}
+		atomic.AddInt64(&client.cacheMisses, 1)
+	}
+
+	res, err := client.DoCtx(ctx, req)
+	if err == nil && client.Cache != nil && !mod.noCache {
+		ttl := client.CacheTTL
+		if ttl == 0 {
+			ttl = DefaultCacheTTL
+		}
+		client.Cache.Set(key, res, ttl)
+	}
+	return res, err
 }
 
 // Delete makes a DELETE request.
 func (client *Client) Delete(path string, mods ...func(*Req)) (Res, error) {
-	req := client.NewReq("DELETE", path, nil, mods...)
-	return client.Do(req)
+	return client.DeleteCtx(context.Background(), path, mods...)
+}
+
+// DeleteCtx makes a DELETE request bound to ctx. On success it invalidates
+// both the exact cache entry for path and the parent collection's list entry.
+func (client *Client) DeleteCtx(ctx context.Context, path string, mods ...func(*Req)) (Res, error) {
+	req := client.NewReqCtx(ctx, "DELETE", path, nil, mods...)
+	res, err := client.DoCtx(ctx, req)
+	if err == nil && client.Cache != nil {
+		client.Cache.Invalidate(client.Url + path)
+		client.Cache.Invalidate(client.Url + collectionPrefix(path))
+	}
+	return res, err
 }
 
-// Post makes a POST request and returns a GJSON result.
-// Hint: Use the Body struct to easily create POST body data.
-func (client *Client) Post(path, data string, mods ...func(*Req)) (Res, error) {
-	req := client.NewReq("POST", path, strings.NewReader(data), mods...)
-	return client.Do(req)
+// Post makes a POST request and returns a GJSON result, along with the
+// Location response header that ISE's ERS API uses to report the URL
+// (and therefore the UUID) of the object it just created.
+func (client *Client) Post(path, data string, mods ...func(*Req)) (Res, string, error) {
+	return client.PostCtx(context.Background(), path, data, mods...)
+}
+
+// PostCtx makes a POST request bound to ctx and returns a GJSON result,
+// along with the Location response header. On success it invalidates the
+// cache entry for path, which POST targets as the collection itself
+// (e.g. POST /ers/config/internaluser), not an item within it.
+func (client *Client) PostCtx(ctx context.Context, path, data string, mods ...func(*Req)) (Res, string, error) {
+	req := client.NewReqCtx(ctx, "POST", path, strings.NewReader(data), mods...)
+	res, header, err := client.do(ctx, req)
+	if err == nil && client.Cache != nil {
+		client.Cache.Invalidate(client.Url + path)
+	}
+	var location string
+	if header != nil {
+		location = header.Get("Location")
+	}
+	return res, location, err
 }
 
 // Put makes a PUT request and returns a GJSON result.
 // Hint: Use the Body struct to easily create PUT body data.
 func (client *Client) Put(path, data string, mods ...func(*Req)) (Res, error) {
-	req := client.NewReq("PUT", path, strings.NewReader(data), mods...)
-	return client.Do(req)
+	return client.PutCtx(context.Background(), path, data, mods...)
+}
+
+// PutCtx makes a PUT request bound to ctx and returns a GJSON result. On
+// success it invalidates the path's collection list entry in the cache.
+func (client *Client) PutCtx(ctx context.Context, path, data string, mods ...func(*Req)) (Res, error) {
+	req := client.NewReqCtx(ctx, "PUT", path, strings.NewReader(data), mods...)
+	res, err := client.DoCtx(ctx, req)
+	if err == nil && client.Cache != nil {
+		client.Cache.Invalidate(client.Url + collectionPrefix(path))
+	}
+	return res, err
 }
 
 // Backoff waits following an exponential backoff algorithm
 func (client *Client) Backoff(attempts int) bool {
-	log.Printf("[DEBUG] Begining backoff method: attempts %v on %v", attempts, client.MaxRetries)
+	return client.BackoffCtx(context.Background(), attempts)
+}
+
+// BackoffCtx waits following an exponential backoff algorithm, returning early
+// with false if ctx is cancelled or its deadline expires before the wait is over.
+func (client *Client) BackoffCtx(ctx context.Context, attempts int) bool {
 	if attempts >= client.MaxRetries {
-		log.Printf("[DEBUG] Exit from backoff method with return value false")
+		client.OnRetry(ctx, &RetryLog{Attempt: attempts + 1, MaxRetries: client.MaxRetries, WillRetry: false})
 		return false
 	}
 
@@ -253,8 +399,15 @@ func (client *Client) Backoff(attempts int) bool {
 	}
 	backoff = (rand.Float64()/2+0.5)*(backoff-min) + min
 	backoffDuration := time.Duration(backoff)
-	log.Printf("[TRACE] Starting sleeping for %v", backoffDuration.Round(time.Second))
-	time.Sleep(backoffDuration)
-	log.Printf("[DEBUG] Exit from backoff method with return value true")
-	return true
+
+	timer := time.NewTimer(backoffDuration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		client.OnRetry(ctx, &RetryLog{Attempt: attempts + 1, MaxRetries: client.MaxRetries, Delay: backoffDuration, WillRetry: true})
+		return true
+	case <-ctx.Done():
+		client.OnRetry(ctx, &RetryLog{Attempt: attempts + 1, MaxRetries: client.MaxRetries, WillRetry: false})
+		return false
+	}
 }
@@ -1,6 +1,8 @@
 package ise
 
 import (
+	"context"
+	"crypto/tls"
 	"errors"
 	"io"
 	"net/http"
@@ -45,6 +47,231 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+// TestClientGetAll tests that GetAll assembles pages into a single merged result.
+func TestClientGetAll(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(testURL).
+		Get("/ers/config/internaluser").
+		MatchParam("page", "1").
+		Reply(200).
+		BodyString(`{"SearchResult":{"total":2,"resources":[{"id":"a"}],"nextPage":{"href":"https://10.0.0.1/ers/config/internaluser?page=2&size=1"}}}`)
+	gock.New(testURL).
+		Get("/ers/config/internaluser").
+		MatchParam("page", "2").
+		Reply(200).
+		BodyString(`{"SearchResult":{"total":2,"resources":[{"id":"b"}]}}`)
+
+	res, err := client.GetAll("/ers/config/internaluser", PageSize(1))
+	assert.NoError(t, err)
+	assert.Len(t, res.Array(), 2)
+	assert.Equal(t, "a", res.Array()[0].Get("id").Str)
+	assert.Equal(t, "b", res.Array()[1].Get("id").Str)
+}
+
+// TestClientGetPages tests that GetPages streams each page to the callback.
+func TestClientGetPages(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(testURL).
+		Get("/ers/config/internaluser").
+		MatchParam("page", "1").
+		Reply(200).
+		BodyString(`{"SearchResult":{"total":1,"resources":[{"id":"a"}]}}`)
+
+	var pages int
+	err := client.GetPages(context.Background(), "/ers/config/internaluser", func(page Res) error {
+		pages++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pages)
+}
+
+// TestClientGetPagesFallsBackToTotal tests that GetPages keeps paging on
+// total alone when a page omits nextPage.href, and stops once total is covered.
+func TestClientGetPagesFallsBackToTotal(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	// Page 1 has no nextPage.href, but total says there's a page 2 to fetch.
+	gock.New(testURL).
+		Get("/ers/config/internaluser").
+		MatchParam("page", "1").
+		Reply(200).
+		BodyString(`{"SearchResult":{"total":2,"resources":[{"id":"a"}]}}`)
+	// Page 2 has neither href nor a total indicating more data: stop here.
+	gock.New(testURL).
+		Get("/ers/config/internaluser").
+		MatchParam("page", "2").
+		Reply(200).
+		BodyString(`{"SearchResult":{"total":2,"resources":[{"id":"b"}]}}`)
+
+	var pages int
+	err := client.GetPages(context.Background(), "/ers/config/internaluser", func(page Res) error {
+		pages++
+		return nil
+	}, PageSize(1))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pages)
+}
+
+// TestClientCache tests that GetCtx serves repeat reads from the cache and
+// that Delete invalidates both the exact entry and its collection.
+func TestClientCache(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient(testURL, "usr", "pwd", MaxRetries(0), WithCache(NewLRUCache(DefaultCacheSize)))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(testURL).Get("/ers/config/internaluser/abc").Times(1).Reply(200).BodyString(`{"id":"abc"}`)
+	_, err := client.Get("/ers/config/internaluser/abc")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, client.CacheMisses())
+
+	// Served from the cache: no second gock mock needed.
+	res, err := client.Get("/ers/config/internaluser/abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", res.Get("id").Str)
+	assert.EqualValues(t, 1, client.CacheHits())
+
+	gock.New(testURL).Delete("/ers/config/internaluser/abc").Reply(200)
+	_, err = client.Delete("/ers/config/internaluser/abc")
+	assert.NoError(t, err)
+
+	// Cache was invalidated by the delete, so this requires a fresh mock.
+	gock.New(testURL).Get("/ers/config/internaluser/abc").Times(1).Reply(200).BodyString(`{"id":"abc"}`)
+	_, err = client.Get("/ers/config/internaluser/abc")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, client.CacheMisses())
+}
+
+// TestClientPostDoesNotInvalidateUnrelatedCollections tests that POST only
+// invalidates the collection it targets, not sibling collections that
+// happen to share a URL prefix.
+func TestClientPostDoesNotInvalidateUnrelatedCollections(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient(testURL, "usr", "pwd", MaxRetries(0), WithCache(NewLRUCache(DefaultCacheSize)))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(testURL).Get("/ers/config/networkdevice").Times(1).Reply(200).BodyString(`{}`)
+	_, err := client.Get("/ers/config/networkdevice")
+	assert.NoError(t, err)
+
+	gock.New(testURL).Post("/ers/config/internaluser").Reply(200).Header.Add("Location", "abc")
+	_, _, err = client.Post("/ers/config/internaluser", "{}")
+	assert.NoError(t, err)
+
+	// Still served from the cache: no second gock mock for networkdevice needed.
+	_, err = client.Get("/ers/config/networkdevice")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, client.CacheMisses())
+}
+
+// TestClientPutDeleteDoNotInvalidateUnrelatedCollections tests that Put and
+// Delete only invalidate their own collection, not a sibling collection whose
+// name happens to extend it as a string (e.g. sgt vs sgtgroup).
+func TestClientPutDeleteDoNotInvalidateUnrelatedCollections(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient(testURL, "usr", "pwd", MaxRetries(0), WithCache(NewLRUCache(DefaultCacheSize)))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(testURL).Get("/ers/config/sgtgroup").Times(1).Reply(200).BodyString(`{}`)
+	_, err := client.Get("/ers/config/sgtgroup")
+	assert.NoError(t, err)
+
+	gock.New(testURL).Put("/ers/config/sgt/abc-id").Reply(200)
+	_, err = client.Put("/ers/config/sgt/abc-id", "{}")
+	assert.NoError(t, err)
+
+	// Still served from the cache: no second gock mock for sgtgroup needed.
+	_, err = client.Get("/ers/config/sgtgroup")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, client.CacheMisses())
+
+	gock.New(testURL).Delete("/ers/config/sgt/abc-id").Reply(200)
+	_, err = client.Delete("/ers/config/sgt/abc-id")
+	assert.NoError(t, err)
+
+	// Delete of sgt/abc-id still must not evict sgtgroup either.
+	_, err = client.Get("/ers/config/sgtgroup")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, client.CacheMisses())
+}
+
+// TestClientTLSModifiers tests that TLS modifiers flip InsecureSkipVerify to false.
+func TestClientTLSModifiers(t *testing.T) {
+	client, _ := NewClient(testURL, "usr", "pwd", ServerName("ise.example.com"))
+	tr := client.HttpClient.Transport.(*http.Transport)
+	assert.False(t, tr.TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, "ise.example.com", tr.TLSClientConfig.ServerName)
+}
+
+// TestClientTLSConfigMergesWholeStruct tests that TLSConfig carries over fields
+// beyond RootCAs/Certificates/ServerName, while still forcing verification on.
+func TestClientTLSConfigMergesWholeStruct(t *testing.T) {
+	client, _ := NewClient(testURL, "usr", "pwd", TLSConfig(&tls.Config{
+		MinVersion:         tls.VersionTLS13,
+		InsecureSkipVerify: true,
+	}))
+	tr := client.HttpClient.Transport.(*http.Transport)
+	assert.Equal(t, uint16(tls.VersionTLS13), tr.TLSClientConfig.MinVersion)
+	assert.False(t, tr.TLSClientConfig.InsecureSkipVerify)
+}
+
+// TestClientTLSModifierErrors tests that malformed input to RootCAsFromPEM and
+// ClientCertificateFromFiles surfaces as NewClient's error, rather than
+// silently leaving the client unable to verify any certificate.
+func TestClientTLSModifierErrors(t *testing.T) {
+	_, err := NewClient(testURL, "usr", "pwd", RootCAsFromPEM([]byte("not a pem bundle")))
+	assert.Error(t, err)
+
+	_, err = NewClient(testURL, "usr", "pwd", ClientCertificateFromFiles("/no/such/cert.pem", "/no/such/key.pem"))
+	assert.Error(t, err)
+}
+
+// TestClientHooks tests that WithRequestHook and WithResponseHook are invoked for each attempt.
+func TestClientHooks(t *testing.T) {
+	defer gock.Off()
+	var reqs []RequestLog
+	var ress []ResponseLog
+	client, _ := NewClient(testURL, "usr", "pwd", MaxRetries(0),
+		WithRequestHook(func(ctx context.Context, req *RequestLog) { reqs = append(reqs, *req) }),
+		WithResponseHook(func(ctx context.Context, res *ResponseLog, err error) { ress = append(ress, *res) }),
+	)
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(testURL).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 1)
+	assert.Equal(t, "GET", reqs[0].Method)
+	assert.Len(t, ress, 1)
+	assert.Equal(t, 200, ress[0].Status)
+}
+
+// TestClientRetryHook tests that WithRetryHook observes both a retried and a
+// terminal backoff decision, and that no raw log output is required to see it.
+func TestClientRetryHook(t *testing.T) {
+	defer gock.Off()
+	var retries []RetryLog
+	client, _ := NewClient(testURL, "usr", "pwd", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0),
+		WithRetryHook(func(ctx context.Context, r *RetryLog) { retries = append(retries, *r) }),
+	)
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(testURL).Get("/url").Times(2).Reply(503)
+	_, err := client.Get("/url")
+	assert.Error(t, err)
+	assert.Len(t, retries, 2)
+	assert.True(t, retries[0].WillRetry)
+	assert.False(t, retries[1].WillRetry)
+	// Attempt is 1-based, matching RequestLog/ResponseLog for the same attempt.
+	assert.Equal(t, 1, retries[0].Attempt)
+	assert.Equal(t, 2, retries[1].Attempt)
+}
+
 // TestClientGet tests the Client::Get method.
 func TestClientGet(t *testing.T) {
 	for _, baseURL := range testURLs {
@@ -82,6 +309,18 @@ func TestClientGet(t *testing.T) {
 	}
 }
 
+// TestClientGetCtx tests that Client::GetCtx aborts as soon as ctx is cancelled.
+func TestClientGetCtx(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.GetCtx(ctx, "/url")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 // TestClientDelete tests the Client::Delete method.
 func TestClientDelete(t *testing.T) {
 	for _, baseURL := range testURLs {
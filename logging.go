@@ -0,0 +1,119 @@
+package ise
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+)
+
+// RequestLog captures the details of an outgoing HTTP request so that an
+// OnRequest hook can forward them to the caller's logger of choice.
+type RequestLog struct {
+	// Method is the HTTP method, e.g. GET or POST.
+	Method string
+	// URL is the full request URL.
+	URL string
+	// Header is the request header set.
+	Header http.Header
+	// Body is the request payload. Empty unless payload logging is enabled for the request.
+	Body string
+	// Attempt is the 1-based attempt number, incremented on every retry.
+	Attempt int
+}
+
+// ResponseLog captures the details of an HTTP response, or of a failed
+// attempt, so that an OnResponse hook can forward them to the caller's
+// logger of choice.
+type ResponseLog struct {
+	// Method is the HTTP method of the request that produced this response.
+	Method string
+	// URL is the full request URL of the request that produced this response.
+	URL string
+	// Header is the response header set. Unset if no response was received.
+	Header http.Header
+	// Body is the response payload. Empty unless payload logging is enabled for the request.
+	Body string
+	// Status is the HTTP status code. Unset if no response was received.
+	Status int
+	// ErrorMessage is the ERS error title parsed out of the response body, set
+	// only when Status is outside the 2xx range.
+	ErrorMessage string
+	// Duration is how long the attempt took.
+	Duration time.Duration
+	// Attempt is the 1-based attempt number, incremented on every retry.
+	Attempt int
+	// WillRetry reports whether Do will retry after this attempt.
+	WillRetry bool
+}
+
+// RetryLog captures a single Backoff decision so that an OnRetry hook can
+// forward it to the caller's logger of choice.
+type RetryLog struct {
+	// Attempt is the 1-based attempt number that just failed, matching
+	// RequestLog.Attempt/ResponseLog.Attempt for the same HTTP attempt.
+	Attempt int
+	// MaxRetries is the client's configured maximum number of retries.
+	MaxRetries int
+	// Delay is how long Backoff waited before allowing the next attempt. Zero if WillRetry is false.
+	Delay time.Duration
+	// WillRetry reports whether Backoff will wait and allow another attempt.
+	WillRetry bool
+}
+
+// defaultRequestLogHook preserves the library's historical log.Printf debug output.
+func defaultRequestLogHook(ctx context.Context, req *RequestLog) {
+	if req.Body != "" {
+		log.Printf("[DEBUG] HTTP Request: %s, %s, %s", req.Method, req.URL, req.Body)
+	} else {
+		log.Printf("[DEBUG] HTTP Request: %s, %s", req.Method, req.URL)
+	}
+}
+
+// defaultResponseLogHook preserves the library's historical log.Printf debug/error output.
+func defaultResponseLogHook(ctx context.Context, res *ResponseLog, err error) {
+	switch {
+	case err != nil && res.Duration == 0:
+		// ctx was already cancelled before this attempt was even made.
+		log.Printf("[DEBUG] Exit from Do method: %s", err)
+		return
+	case err != nil && res.Status == 0:
+		if res.WillRetry {
+			log.Printf("[ERROR] HTTP Connection failed: %s, retries: %v", err, res.Attempt-1)
+		} else {
+			log.Printf("[ERROR] HTTP Connection error occured: %+v", err)
+		}
+	case err != nil:
+		if res.WillRetry {
+			log.Printf("[ERROR] Cannot decode response body: %s, retries: %v", err, res.Attempt-1)
+		} else {
+			log.Printf("[ERROR] Cannot decode response body: %+v", err)
+		}
+	case res.Status < 200 || res.Status > 299:
+		if res.WillRetry {
+			log.Printf("[ERROR] HTTP Request failed: StatusCode %v, Message: %v, Retries: %v", res.Status, res.ErrorMessage, res.Attempt-1)
+		} else {
+			log.Printf("[ERROR] HTTP Request failed: StatusCode %v, Message: %v", res.Status, res.ErrorMessage)
+		}
+	default:
+		if res.Body != "" {
+			log.Printf("[DEBUG] HTTP Response: %s", res.Body)
+		}
+	}
+	if !res.WillRetry {
+		log.Printf("[DEBUG] Exit from Do method")
+	}
+}
+
+// defaultRetryLogHook preserves the library's historical log.Printf debug/trace output.
+// It runs once the backoff wait is over, so all of its lines, including the
+// "Starting sleeping" one, are printed after the sleep rather than around it.
+func defaultRetryLogHook(ctx context.Context, r *RetryLog) {
+	log.Printf("[DEBUG] Begining backoff method: attempts %v on %v", r.Attempt-1, r.MaxRetries)
+	if !r.WillRetry {
+		log.Printf("[DEBUG] Exit from backoff method with return value false")
+		return
+	}
+	log.Printf("[TRACE] Starting sleeping for %v", r.Delay.Round(time.Second))
+	log.Printf("[DEBUG] Exit from backoff method with return value true")
+}
@@ -0,0 +1,78 @@
+package ise
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// DefaultPageSize is the page size used by GetAll and GetPages when no PageSize modifier is given.
+const DefaultPageSize = 100
+
+type pageSizeKey struct{}
+
+// PageSize overrides the page size used by GetAll and GetPages, from the default of DefaultPageSize.
+func PageSize(x int) func(*Req) {
+	return func(req *Req) {
+		req.HttpReq = req.HttpReq.WithContext(context.WithValue(req.HttpReq.Context(), pageSizeKey{}, x))
+	}
+}
+
+// GetAll walks every page of an ERS list endpoint and returns the concatenated
+// SearchResult.resources as a single synthetic JSON array.
+func (client *Client) GetAll(path string, mods ...func(*Req)) (Res, error) {
+	var raws []string
+	err := client.GetPages(context.Background(), path, func(page Res) error {
+		for _, resource := range page.Get("SearchResult.resources").Array() {
+			raws = append(raws, resource.Raw)
+		}
+		return nil
+	}, mods...)
+	if err != nil {
+		return Res{}, err
+	}
+	return Res(gjson.Parse("[" + strings.Join(raws, ",") + "]")), nil
+}
+
+// GetPages walks every page of an ERS list endpoint, invoking fn with each
+// page's raw result as it arrives, without holding the full list in memory.
+// It respects ctx cancellation and the usual retry/backoff for each page fetch.
+func (client *Client) GetPages(ctx context.Context, path string, fn func(Res) error, mods ...func(*Req)) error {
+	probe := client.NewReqCtx(ctx, "GET", path, nil, mods...)
+	pageSize := DefaultPageSize
+	if x, ok := probe.HttpReq.Context().Value(pageSizeKey{}).(int); ok && x > 0 {
+		pageSize = x
+	}
+
+	u, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("size", strconv.Itoa(pageSize))
+
+	for page := 1; ; page++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		q.Set("page", strconv.Itoa(page))
+		u.RawQuery = q.Encode()
+
+		res, err := client.GetCtx(ctx, u.String(), mods...)
+		if err != nil {
+			return err
+		}
+		if err := fn(res); err != nil {
+			return err
+		}
+
+		total := res.Get("SearchResult.total").Int()
+		hrefExists := res.Get("SearchResult.nextPage.href").Exists()
+		if !hrefExists && total <= int64(page*pageSize) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package ise
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+)
+
+// tlsConfig returns the client's underlying *tls.Config, so that TLS modifiers
+// can be written against the Client without reaching into the Transport directly.
+func tlsConfig(client *Client) *tls.Config {
+	return client.HttpClient.Transport.(*http.Transport).TLSClientConfig
+}
+
+// RootCAs pins the set of root CAs used to verify the ISE server certificate,
+// e.g. ISE's admin CA, instead of relying on the system trust store. Setting
+// this flips the default of InsecureSkipVerify to false.
+func RootCAs(pool *x509.CertPool) func(*Client) {
+	return func(client *Client) {
+		tlsConfig(client).RootCAs = pool
+		tlsConfig(client).InsecureSkipVerify = false
+	}
+}
+
+// RootCAsFromPEM is a convenience wrapper around RootCAs that loads root CAs
+// from a PEM-encoded bundle. If pem contains no usable certificates, the
+// client is left on its insecure default and NewClient returns an error.
+func RootCAsFromPEM(pem []byte) func(*Client) {
+	return func(client *Client) {
+		pool := x509.NewCertPool()
+		if ok := pool.AppendCertsFromPEM(pem); !ok {
+			client.setModErr(fmt.Errorf("ise: RootCAsFromPEM: no certificates found in PEM data"))
+			return
+		}
+		RootCAs(pool)(client)
+	}
+}
+
+// ClientCertificate presents the given certificate(s) to the ISE server,
+// for pxGrid-style mutual TLS. Setting this flips the default of
+// InsecureSkipVerify to false.
+func ClientCertificate(certs ...tls.Certificate) func(*Client) {
+	return func(client *Client) {
+		tlsConfig(client).Certificates = append(tlsConfig(client).Certificates, certs...)
+		tlsConfig(client).InsecureSkipVerify = false
+	}
+}
+
+// ClientCertificateFromFiles is a convenience wrapper around ClientCertificate
+// that loads a PEM-encoded certificate and key from disk. If loading fails,
+// the client is left on its insecure default and NewClient returns an error.
+func ClientCertificateFromFiles(certPath, keyPath string) func(*Client) {
+	return func(client *Client) {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			client.setModErr(fmt.Errorf("ise: ClientCertificateFromFiles: %w", err))
+			return
+		}
+		ClientCertificate(cert)(client)
+	}
+}
+
+// ServerName overrides the server name used to verify the ISE server
+// certificate, e.g. when connecting via an IP address or a load balancer.
+// Setting this flips the default of InsecureSkipVerify to false.
+func ServerName(name string) func(*Client) {
+	return func(client *Client) {
+		tlsConfig(client).ServerName = name
+		tlsConfig(client).InsecureSkipVerify = false
+	}
+}
+
+// TLSConfig is a low-level escape hatch: it replaces the client's TLS
+// configuration wholesale with a copy of cfg, so fields RootCAs/ClientCertificate/
+// ServerName don't cover (MinVersion, CipherSuites, NextProtos, ClientAuth, etc.)
+// still take effect. InsecureSkipVerify is always forced back to false, even if
+// cfg sets it true, so this can't silently reintroduce the insecure default.
+func TLSConfig(cfg *tls.Config) func(*Client) {
+	return func(client *Client) {
+		current := tlsConfig(client)
+		*current = *cfg
+		current.InsecureSkipVerify = false
+	}
+}